@@ -0,0 +1,77 @@
+package aliyun_rocketmq_go
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/rocketmq-client-go/v2/admin"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+)
+
+// Admin 基于 rocketmq-client-go/v2/admin 封装的运维管理客户端，与 RocketHelper 共享同一套
+// endpoint/instanceId/credentials 配置，便于在不引入额外依赖的情况下完成 Topic、消费组的管理操作。
+type Admin struct {
+	opts  *RocketHelperOptions
+	inner admin.Admin
+}
+
+func (rh *RocketHelper) NewAdmin() (*Admin, error) {
+	opts := rh.opts
+	opts.logger().Infow("create-rocketmq-admin",
+		"endpoint", opts.Endpoint,
+		"instanceId", opts.InstanceId,
+	)
+	inner, err := admin.NewAdmin(
+		admin.WithResolver(primitive.NewPassthroughResolver([]string{opts.Endpoint})),
+		admin.WithCredentials(opts.GetCredentials()),
+		admin.WithNamespace(opts.InstanceId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Admin{opts: opts, inner: inner}, nil
+}
+
+// CreateTopic 在 brokerAddr 指定的 Broker 上创建 Topic，queueNum 为读写队列数。brokerAddr 是
+// Broker 的直连地址（与 FetchSubscriptionGroups 的 brokerAddr 参数含义相同），
+// 与 opts.Endpoint 所配置的 nameserver 接入点是两个不同的地址，不能互相替代。
+func (a *Admin) CreateTopic(ctx context.Context, brokerAddr string, topic string, queueNum int) error {
+	a.opts.logger().Infow("create-rocketmq-topic", "brokerAddr", brokerAddr, "topic", topic, "queueNum", queueNum)
+	return a.inner.CreateTopic(ctx,
+		admin.WithTopicCreate(topic),
+		admin.WithReadQueueNums(queueNum),
+		admin.WithWriteQueueNums(queueNum),
+		admin.WithBrokerAddrCreate(brokerAddr),
+	)
+}
+
+// DeleteTopic 删除指定 Topic
+func (a *Admin) DeleteTopic(ctx context.Context, topic string) error {
+	a.opts.logger().Infow("delete-rocketmq-topic", "topic", topic)
+	return a.inner.DeleteTopic(ctx,
+		admin.WithTopicDelete(topic),
+		admin.WithNameSrvAddr([]string{a.opts.Endpoint}),
+	)
+}
+
+// FetchAllTopicList 获取集群下所有 Topic 列表
+func (a *Admin) FetchAllTopicList(ctx context.Context) (*admin.TopicList, error) {
+	return a.inner.FetchAllTopicList(ctx)
+}
+
+// FetchSubscriptionGroups 获取指定 Broker 上所有消费组的订阅配置，用于排查消费组是否存在、
+// 消费模式及重试策略等配置是否符合预期
+func (a *Admin) FetchSubscriptionGroups(ctx context.Context, brokerAddr string, timeout time.Duration) (*admin.SubscriptionGroupWrapper, error) {
+	a.opts.logger().Debugw("fetch-rocketmq-subscription-groups", "brokerAddr", brokerAddr)
+	return a.inner.GetAllSubscriptionGroup(ctx, brokerAddr, timeout)
+}
+
+// FetchPublishMessageQueues 获取指定 Topic 下用于发送消息的队列列表
+func (a *Admin) FetchPublishMessageQueues(ctx context.Context, topic string) ([]*primitive.MessageQueue, error) {
+	return a.inner.FetchPublishMessageQueues(ctx, topic)
+}
+
+// Close 关闭 Admin 客户端底层连接
+func (a *Admin) Close() error {
+	return a.inner.Close()
+}
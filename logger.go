@@ -0,0 +1,89 @@
+package aliyun_rocketmq_go
+
+import (
+	"log"
+
+	"go.uber.org/zap"
+)
+
+// Logger 是本模块内所有日志输出点使用的统一接口，参数约定与 zap.SugaredLogger 一致：
+// msg 为事件名，keysAndValues 为交替出现的 key/value 对。RocketHelperOptions 不设置 Logger 时
+// 默认使用 noopLogger，即不输出任何日志，避免在库代码中强加特定的日志实现。
+type Logger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Debugw(string, ...interface{}) {}
+func (noopLogger) Infow(string, ...interface{})  {}
+func (noopLogger) Warnw(string, ...interface{})  {}
+func (noopLogger) Errorw(string, ...interface{}) {}
+
+// logger 返回 opts 上配置的 Logger，未配置时返回 noopLogger
+func (opts *RocketHelperOptions) logger() Logger {
+	if opts.Logger == nil {
+		return noopLogger{}
+	}
+	return opts.Logger
+}
+
+// ZapLogger 是基于 zap.SugaredLogger 的 Logger 适配器
+type ZapLogger struct {
+	l *zap.SugaredLogger
+}
+
+// NewZapLogger 将 *zap.Logger 适配为 Logger
+func NewZapLogger(l *zap.Logger) *ZapLogger {
+	return &ZapLogger{l: l.Sugar()}
+}
+
+func (z *ZapLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	z.l.Debugw(msg, keysAndValues...)
+}
+
+func (z *ZapLogger) Infow(msg string, keysAndValues ...interface{}) {
+	z.l.Infow(msg, keysAndValues...)
+}
+
+func (z *ZapLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	z.l.Warnw(msg, keysAndValues...)
+}
+
+func (z *ZapLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	z.l.Errorw(msg, keysAndValues...)
+}
+
+// StdLogger 是基于标准库 log.Logger 的 Logger 适配器，按 level、msg、keysAndValues 的顺序打印一行
+type StdLogger struct {
+	l *log.Logger
+}
+
+// NewStdLogger 将标准库 *log.Logger 适配为 Logger
+func NewStdLogger(l *log.Logger) *StdLogger {
+	return &StdLogger{l: l}
+}
+
+func (s *StdLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	s.print("DEBUG", msg, keysAndValues...)
+}
+
+func (s *StdLogger) Infow(msg string, keysAndValues ...interface{}) {
+	s.print("INFO", msg, keysAndValues...)
+}
+
+func (s *StdLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	s.print("WARN", msg, keysAndValues...)
+}
+
+func (s *StdLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	s.print("ERROR", msg, keysAndValues...)
+}
+
+func (s *StdLogger) print(level string, msg string, keysAndValues ...interface{}) {
+	args := append([]interface{}{level, msg}, keysAndValues...)
+	s.l.Println(args...)
+}
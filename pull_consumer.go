@@ -0,0 +1,132 @@
+package aliyun_rocketmq_go
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+)
+
+func (rh *RocketHelper) CreatePullConsumer() (rocketmq.PullConsumer, error) {
+	opts := rh.opts
+	opts.logger().Infow("create-rocketmq-pull-consumer",
+		"endpoint", opts.Endpoint,
+		"instanceId", opts.InstanceId,
+		"groupId", opts.GroupId,
+	)
+	return rocketmq.NewPullConsumer(
+		consumer.WithNameServer([]string{opts.Endpoint}),
+		consumer.WithNamespace(opts.InstanceId),
+		consumer.WithInstance(opts.InstanceId),
+		consumer.WithGroupName(opts.GroupId),
+		consumer.WithCredentials(opts.GetCredentials()),
+		consumer.WithConsumeFromWhere(consumer.ConsumeFromWhere(opts.ConsumeFrom)),
+	)
+}
+
+func (rh *RocketHelper) getPullConsumer() (rocketmq.PullConsumer, error) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	if rh.opts.MultipleInstances {
+		c, err := rh.CreatePullConsumer()
+		if err != nil {
+			return nil, err
+		}
+		rh.extraPullConsumers = append(rh.extraPullConsumers, c)
+		return c, nil
+	}
+	if rh.pullConsumer == nil {
+		c, err := rh.CreatePullConsumer()
+		if err != nil {
+			return nil, err
+		}
+		rh.pullConsumer = c
+	}
+	return rh.pullConsumer, nil
+}
+
+// SeekOffset 将指定 MessageQueue 在内存中的下次拉取位点重置到 offset，不会立即持久化到 Broker，
+// 调用 CommitOffset 或 PullConsumer 自身的定时持久化逻辑才会将其落盘
+func (rh *RocketHelper) SeekOffset(mq *primitive.MessageQueue, offset int64) error {
+	c, err := rh.getPullConsumer()
+	if err != nil {
+		return err
+	}
+	return c.UpdateOffset(mq, offset)
+}
+
+// CommitOffset 将指定 MessageQueue 的消费位点更新为 offset 并立即持久化到 Broker
+func (rh *RocketHelper) CommitOffset(mq *primitive.MessageQueue, offset int64) error {
+	c, err := rh.getPullConsumer()
+	if err != nil {
+		return err
+	}
+	if err = c.UpdateOffset(mq, offset); err != nil {
+		return err
+	}
+	return c.PersistOffset(context.Background(), mq.Topic)
+}
+
+// PullFrom 从指定 MessageQueue 的 offset 处拉取最多 numbers 条消息，由调用方自行管理队列分配和位点，
+// 适合需要精细控制拉取节奏的场景
+func (rh *RocketHelper) PullFrom(mq *primitive.MessageQueue, offset int64, numbers int) (*primitive.PullResult, error) {
+	c, err := rh.getPullConsumer()
+	if err != nil {
+		return nil, err
+	}
+	return c.PullFrom(context.Background(), mq, offset, numbers)
+}
+
+// PullConsume 按 tagFilter 订阅 topic 并持续拉取消息，每批消息处理完成后按照 onMessage 的返回值
+// ACK 成功或标记稍后重试。相比 PushConsume，调用方通过循环节奏自己掌控拉取速度，适合对下游有
+// 背压要求的场景。
+func (rh *RocketHelper) PullConsume(topic string, tagFilter string, onMessage func(mq *primitive.MessageQueue, messages []*primitive.MessageExt) error) error {
+	c, err := rh.getPullConsumer()
+	if err != nil {
+		return err
+	}
+	logger := rh.opts.logger()
+
+	if err = c.Subscribe(topic, consumer.MessageSelector{
+		Type:       consumer.TAG,
+		Expression: tagFilter,
+	}); err != nil {
+		return err
+	}
+	if err = c.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Shutdown()
+		rh.mu.Lock()
+		if rh.pullConsumer == c {
+			rh.pullConsumer = nil
+		}
+		rh.mu.Unlock()
+	}()
+
+	for {
+		cr, err := c.Poll(context.Background(), 5*time.Second)
+		if err != nil {
+			if err == consumer.ErrNoNewMsg {
+				continue
+			}
+			return err
+		}
+
+		logger.Debugw("poll-rocketmq-message",
+			"topic", cr.GetMQ().Topic,
+			"queueId", cr.GetMQ().QueueId,
+			"count", len(cr.GetMsgList()),
+		)
+		if err = onMessage(cr.GetMQ(), cr.GetMsgList()); err != nil {
+			logger.Warnw("process-pulled-messages-failed", "error", err)
+			c.ACK(context.Background(), cr, consumer.ConsumeRetryLater)
+			continue
+		}
+		c.ACK(context.Background(), cr, consumer.ConsumeSuccess)
+	}
+}
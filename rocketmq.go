@@ -2,12 +2,13 @@ package aliyun_rocketmq_go
 
 import (
 	"context"
+	"errors"
+	"sync"
+
 	"github.com/apache/rocketmq-client-go/v2"
 	"github.com/apache/rocketmq-client-go/v2/consumer"
 	"github.com/apache/rocketmq-client-go/v2/primitive"
 	"github.com/apache/rocketmq-client-go/v2/producer"
-	"github.com/zhangsq-ax/logs"
-	"go.uber.org/zap"
 )
 
 type ConsumeFrom consumer.ConsumeFromWhere
@@ -17,6 +18,36 @@ const (
 	ConsumeFromFirst ConsumeFrom = ConsumeFrom(consumer.ConsumeFromFirstOffset)
 )
 
+// MessageModel 与 consumer.MessageModel 数值故意不一致：Clustering 取零值，以保证
+// RocketHelperOptions.MessageModel 未显式设置时默认按 Clustering（分组负载均衡）消费，
+// 而不是 SDK 里零值对应的 BroadCasting。两者之间的换算见 toSDK。
+type MessageModel int
+
+const (
+	Clustering MessageModel = iota
+	Broadcasting
+)
+
+func (m MessageModel) toSDK() consumer.MessageModel {
+	if m == Broadcasting {
+		return consumer.BroadCasting
+	}
+	return consumer.Clustering
+}
+
+// ConsumeDecision 表示消费失败后的处理策略，由 RetryPolicy 回调决定
+type ConsumeDecision int
+
+const (
+	// ConsumeRetryLater 消息稍后重新投递
+	ConsumeRetryLater ConsumeDecision = iota
+	// ConsumeSuspend 不再重试，交由调用方在回调中自行转存死信/告警后确认消费成功
+	ConsumeSuspend
+)
+
+// RetryPolicy 根据消费失败次数等信息决定消息是否继续重试，默认总是返回 ConsumeRetryLater
+type RetryPolicy func(msg *primitive.MessageExt, consumeErr error) ConsumeDecision
+
 type RocketHelperOptions struct {
 	Endpoint        string      // Aliyun RocketMQ 服务接入点
 	InstanceId      string      // Aliyun RocketMQ 服务实例标识
@@ -24,6 +55,26 @@ type RocketHelperOptions struct {
 	ConsumeFrom     ConsumeFrom // 初次消息消费开始位置
 	AccessKeyId     string
 	AccessKeySecret string
+
+	// MultipleInstances 为 true 时，每次 getXXX 调用都会创建新的底层客户端而不是复用缓存，
+	// 新建出的客户端会被记录下来，统一在 Close 时关闭。用于同一进程内需要并行持有多套
+	// 生产者/消费者连接（例如多个 Aliyun 实例）的场景；开启后应通过高层辅助方法批量操作，
+	// 而不是在高频路径（如逐条发送消息）上使用，否则每次调用都会新建一个客户端。
+	MultipleInstances bool
+
+	MessageModel MessageModel // 消费模式，零值为 Clustering
+
+	// Orderly 为 true 时按队列顺序消费，为 false（零值）时使用并发消费。
+	// 注意：该字段引入前本库固定按顺序消费（等价于 Orderly: true），
+	// 新增该选项后未显式设置的调用方会得到并发消费，属于行为变化，升级时请确认是否需要显式设为 true。
+	Orderly bool
+
+	MaxReconsumeTimes          int32       // 消费失败最大重试次数，<=0 时使用 SDK 默认值
+	ConsumeGoroutineNums       int         // 并发消费协程数，<=0 时使用 SDK 默认值
+	ConsumeMessageBatchMaxSize int         // 单次回调处理的最大消息条数，<=0 时使用 SDK 默认值
+	RetryPolicy                RetryPolicy // 消费失败后的重试策略，默认总是 ConsumeRetryLater
+
+	Logger Logger // 日志输出接口，未设置时不输出任何日志
 }
 
 func (opts *RocketHelperOptions) GetCredentials() primitive.Credentials {
@@ -35,6 +86,20 @@ func (opts *RocketHelperOptions) GetCredentials() primitive.Credentials {
 
 type RocketHelper struct {
 	opts *RocketHelperOptions
+
+	mu                  sync.Mutex
+	pushConsumer        rocketmq.PushConsumer
+	producer            rocketmq.Producer
+	pullConsumer        rocketmq.PullConsumer
+	transactionProducer rocketmq.TransactionProducer
+
+	// extraPushConsumers/extraProducers/extraPullConsumers/extraTransactionProducers 记录
+	// opts.MultipleInstances 为 true 时创建出的、不进入上面单例缓存字段的额外客户端，仅用于
+	// 在 Close 时把它们一并关闭，避免泄漏。
+	extraPushConsumers        []rocketmq.PushConsumer
+	extraProducers            []rocketmq.Producer
+	extraPullConsumers        []rocketmq.PullConsumer
+	extraTransactionProducers []rocketmq.TransactionProducer
 }
 
 func NewRocketHelper(opts *RocketHelperOptions) *RocketHelper {
@@ -43,61 +108,143 @@ func NewRocketHelper(opts *RocketHelperOptions) *RocketHelper {
 	}
 }
 
+// Close 关闭当前 RocketHelper 缓存的生产者、推送消费者、拉取消费者、事务生产者，以及
+// opts.MultipleInstances 为 true 时额外创建的所有客户端。未创建过的客户端会被跳过。可安全重复调用。
+// 单个客户端 Shutdown 失败不会中断其余客户端的关闭，所有错误会通过 errors.Join 聚合返回。
+func (rh *RocketHelper) Close() error {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	var errs []error
+
+	if rh.pushConsumer != nil {
+		if err := rh.pushConsumer.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+		rh.pushConsumer = nil
+	}
+	if rh.producer != nil {
+		if err := rh.producer.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+		rh.producer = nil
+	}
+	if rh.pullConsumer != nil {
+		if err := rh.pullConsumer.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+		rh.pullConsumer = nil
+	}
+	if rh.transactionProducer != nil {
+		if err := rh.transactionProducer.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+		rh.transactionProducer = nil
+	}
+	for _, c := range rh.extraPushConsumers {
+		if err := c.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	rh.extraPushConsumers = nil
+	for _, p := range rh.extraProducers {
+		if err := p.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	rh.extraProducers = nil
+	for _, c := range rh.extraPullConsumers {
+		if err := c.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	rh.extraPullConsumers = nil
+	for _, tp := range rh.extraTransactionProducers {
+		if err := tp.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	rh.extraTransactionProducers = nil
+	return errors.Join(errs...)
+}
+
+// Shutdown 是 Close 的别名，与底层 rocketmq-client-go 客户端的方法命名保持一致
+func (rh *RocketHelper) Shutdown() error {
+	return rh.Close()
+}
+
 func (rh *RocketHelper) CreatePushConsumer() (rocketmq.PushConsumer, error) {
 	opts := rh.opts
-	logs.Infow("create-rocketmq-push-consumer",
-		zap.Reflect("options", map[string]interface{}{
-			"endpoint":   opts.Endpoint,
-			"instanceId": opts.InstanceId,
-			"groupId":    opts.GroupId,
-		}),
+	opts.logger().Infow("create-rocketmq-push-consumer",
+		"endpoint", opts.Endpoint,
+		"instanceId", opts.InstanceId,
+		"groupId", opts.GroupId,
 	)
-	return rocketmq.NewPushConsumer(
+	consumerOpts := []consumer.Option{
 		consumer.WithNameServer([]string{opts.Endpoint}),
 		consumer.WithNamespace(opts.InstanceId),
 		consumer.WithInstance(opts.InstanceId),
 		consumer.WithGroupName(opts.GroupId),
 		consumer.WithCredentials(opts.GetCredentials()),
-		consumer.WithConsumerModel(consumer.Clustering),
+		consumer.WithConsumerModel(opts.MessageModel.toSDK()),
 		consumer.WithConsumeFromWhere(consumer.ConsumeFromWhere(opts.ConsumeFrom)),
-		consumer.WithConsumerOrder(true),
-	)
+		consumer.WithConsumerOrder(opts.Orderly),
+	}
+	if opts.MaxReconsumeTimes > 0 {
+		consumerOpts = append(consumerOpts, consumer.WithMaxReconsumeTimes(opts.MaxReconsumeTimes))
+	}
+	if opts.ConsumeGoroutineNums > 0 {
+		consumerOpts = append(consumerOpts, consumer.WithConsumeGoroutineNums(opts.ConsumeGoroutineNums))
+	}
+	if opts.ConsumeMessageBatchMaxSize > 0 {
+		consumerOpts = append(consumerOpts, consumer.WithConsumeMessageBatchMaxSize(opts.ConsumeMessageBatchMaxSize))
+	}
+	return rocketmq.NewPushConsumer(consumerOpts...)
 }
 
-var pushConsumer rocketmq.PushConsumer
-
 func (rh *RocketHelper) getPushConsumer() (rocketmq.PushConsumer, error) {
-	if pushConsumer == nil {
-		var err error
-		pushConsumer, err = rh.CreatePushConsumer()
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	if rh.opts.MultipleInstances {
+		c, err := rh.CreatePushConsumer()
+		if err != nil {
+			return nil, err
+		}
+		rh.extraPushConsumers = append(rh.extraPushConsumers, c)
+		return c, nil
+	}
+	if rh.pushConsumer == nil {
+		c, err := rh.CreatePushConsumer()
 		if err != nil {
 			return nil, err
 		}
+		rh.pushConsumer = c
 	}
-	return pushConsumer, nil
+	return rh.pushConsumer, nil
 }
 
 func (rh *RocketHelper) PushConsumeByConsumer(c rocketmq.PushConsumer, topic string, selector consumer.MessageSelector, onMessage func(*primitive.MessageExt) error) error {
-	logs.Infow("subscribe-rocketmq",
-		zap.String("topic", topic),
-	)
+	logger := rh.opts.logger()
+	logger.Infow("subscribe-rocketmq", "topic", topic)
 	err := c.Subscribe(topic, selector, func(ctx context.Context, messages ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
 		for _, msg := range messages {
-			logs.Debugw("received-rocketmq-message",
-				zap.Reflect("summary", map[string]interface{}{
-					"messageId":      msg.MsgId,
-					"topic":          msg.Topic,
-					"queueOffset":    msg.QueueOffset,
-					"tags":           msg.GetTags(),
-					"keys":           msg.GetKeys(),
-					"properties":     msg.GetProperties(),
-					"regionId":       msg.GetRegionID(),
-					"reconsumeTimes": msg.ReconsumeTimes,
-				}),
+			logger.Debugw("received-rocketmq-message",
+				"messageId", msg.MsgId,
+				"topic", msg.Topic,
+				"queueOffset", msg.QueueOffset,
+				"tags", msg.GetTags(),
+				"keys", msg.GetKeys(),
+				"properties", msg.GetProperties(),
+				"regionId", msg.GetRegionID(),
+				"reconsumeTimes", msg.ReconsumeTimes,
 			)
 			err := onMessage(msg)
 			if err != nil {
-				logs.Warnw("process-message-failed", zap.Error(err))
+				logger.Warnw("process-message-failed", "error", err)
+				if rh.opts.RetryPolicy != nil && rh.opts.RetryPolicy(msg, err) == ConsumeSuspend {
+					continue
+				}
 				return consumer.ConsumeRetryLater, err
 			}
 		}
@@ -111,22 +258,36 @@ func (rh *RocketHelper) PushConsumeByConsumer(c rocketmq.PushConsumer, topic str
 }
 
 func (rh *RocketHelper) PushConsume(topic string, tagFilter string, onMessage func(*primitive.MessageExt) error) error {
+	return rh.PushConsumeWithSelector(topic, consumer.MessageSelector{
+		Type:       consumer.TAG,
+		Expression: tagFilter,
+	}, onMessage)
+}
+
+// PushConsumeSQL 使用 SQL92 表达式过滤消息，支持对消息属性做 Aliyun RocketMQ 支持的服务端过滤，
+// 例如 "a between 0 and 3 and b = 'abc'"
+func (rh *RocketHelper) PushConsumeSQL(topic string, sqlExpr string, onMessage func(*primitive.MessageExt) error) error {
+	return rh.PushConsumeWithSelector(topic, consumer.MessageSelector{
+		Type:       consumer.SQL92,
+		Expression: sqlExpr,
+	}, onMessage)
+}
+
+// PushConsumeWithSelector 使用调用方构造的 MessageSelector 订阅消息，供 TAG/SQL92 等过滤方式复用
+func (rh *RocketHelper) PushConsumeWithSelector(topic string, selector consumer.MessageSelector, onMessage func(*primitive.MessageExt) error) error {
 	c, err := rh.getPushConsumer()
 	if err != nil {
 		return err
 	}
-	return rh.PushConsumeByConsumer(c, topic, consumer.MessageSelector{
-		Type:       consumer.TAG,
-		Expression: tagFilter,
-	}, onMessage)
+	return rh.PushConsumeByConsumer(c, topic, selector, onMessage)
 }
 
 func (rh *RocketHelper) NewProducer() (rocketmq.Producer, error) {
 	opts := rh.opts
-	logs.Infow("create-rocketmq-producer",
-		zap.String("endpoint", opts.Endpoint),
-		zap.String("instanceId", opts.InstanceId),
-		zap.String("groupId", opts.GroupId),
+	opts.logger().Infow("create-rocketmq-producer",
+		"endpoint", opts.Endpoint,
+		"instanceId", opts.InstanceId,
+		"groupId", opts.GroupId,
 	)
 	credentials := opts.GetCredentials()
 	p, err := rocketmq.NewProducer(
@@ -160,17 +321,26 @@ func (rh *RocketHelper) CreatePublicMessage(topic string, body []byte, tag strin
 	return msg.WithTag(tag).WithKeys(keys)
 }
 
-var p rocketmq.Producer
-
 func (rh *RocketHelper) getProducer() (rocketmq.Producer, error) {
-	if p == nil {
-		var err error
-		p, err = rh.NewProducer()
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	if rh.opts.MultipleInstances {
+		p, err := rh.NewProducer()
+		if err != nil {
+			return nil, err
+		}
+		rh.extraProducers = append(rh.extraProducers, p)
+		return p, nil
+	}
+	if rh.producer == nil {
+		p, err := rh.NewProducer()
 		if err != nil {
 			return nil, err
 		}
+		rh.producer = p
 	}
-	return p, nil
+	return rh.producer, nil
 }
 
 func (rh *RocketHelper) SendMessageByProducer(ctx context.Context, p rocketmq.Producer, msg *primitive.Message) (*primitive.SendResult, error) {
@@ -184,3 +354,52 @@ func (rh *RocketHelper) SendMessage(ctx context.Context, msg *primitive.Message)
 	}
 	return rh.SendMessageByProducer(ctx, p, msg)
 }
+
+// SendDelayMessage 发送延时/定时消息，delayLevel 对应 Aliyun RocketMQ 的延时级别（1s..2h）
+func (rh *RocketHelper) SendDelayMessage(ctx context.Context, msg *primitive.Message, delayLevel int) (*primitive.SendResult, error) {
+	msg.WithDelayTimeLevel(delayLevel)
+	return rh.SendMessage(ctx, msg)
+}
+
+// SendBatch 批量发送消息，msgs 必须属于同一个 topic
+func (rh *RocketHelper) SendBatch(ctx context.Context, msgs []*primitive.Message) (*primitive.SendResult, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("msgs must not be empty")
+	}
+	topic := msgs[0].Topic
+	for _, msg := range msgs[1:] {
+		if msg.Topic != topic {
+			return nil, errors.New("all messages in a batch must share the same topic")
+		}
+	}
+
+	p, err := rh.getProducer()
+	if err != nil {
+		return nil, err
+	}
+	rh.opts.logger().Debugw("send-rocketmq-batch-message",
+		"topic", topic,
+		"count", len(msgs),
+	)
+	return p.SendSync(ctx, msgs...)
+}
+
+// SendAsync 异步发送消息，发送结果通过 callback 回调通知
+func (rh *RocketHelper) SendAsync(ctx context.Context, msg *primitive.Message, callback func(ctx context.Context, result *primitive.SendResult, err error)) error {
+	p, err := rh.getProducer()
+	if err != nil {
+		return err
+	}
+	rh.opts.logger().Debugw("send-rocketmq-async-message", "topic", msg.Topic)
+	return p.SendAsync(ctx, callback, msg)
+}
+
+// SendOneway 单向发送消息，不等待 Broker 响应，适用于可容忍丢失的日志类场景
+func (rh *RocketHelper) SendOneway(ctx context.Context, msg *primitive.Message) error {
+	p, err := rh.getProducer()
+	if err != nil {
+		return err
+	}
+	rh.opts.logger().Debugw("send-rocketmq-oneway-message", "topic", msg.Topic)
+	return p.SendOneWay(ctx, msg)
+}
@@ -0,0 +1,18 @@
+package aliyun_rocketmq_go
+
+import (
+	"testing"
+
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+)
+
+func TestMessageModelZeroValueIsClustering(t *testing.T) {
+	var opts RocketHelperOptions
+
+	if opts.MessageModel != Clustering {
+		t.Fatalf("zero value of RocketHelperOptions.MessageModel = %v, want Clustering", opts.MessageModel)
+	}
+	if got := opts.MessageModel.toSDK(); got != consumer.Clustering {
+		t.Fatalf("toSDK() = %v, want consumer.Clustering", got)
+	}
+}
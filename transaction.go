@@ -0,0 +1,87 @@
+package aliyun_rocketmq_go
+
+import (
+	"context"
+	"errors"
+
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+)
+
+// NewTransactionProducer 创建事务消息生产者，listener 需实现 ExecuteLocalTransaction 执行本地事务、
+// CheckLocalTransaction 供 Broker 回查事务最终状态，两者均返回 CommitMessageState/RollbackMessageState/UnknowState。
+// opts.MultipleInstances 为 false 时，重复调用会用新生产者替换掉当前持有的事务生产者，旧的生产者会被
+// 关闭，避免其连接泄漏；为 true 时，每次调用都会创建一个独立的事务生产者且不会关闭之前的实例，全部记录
+// 下来统一在 Close 时关闭，供调用方并行持有多个事务生产者（例如不同 listener）。
+func (rh *RocketHelper) NewTransactionProducer(listener primitive.TransactionListener) (rocketmq.TransactionProducer, error) {
+	opts := rh.opts
+	opts.logger().Infow("create-rocketmq-transaction-producer",
+		"endpoint", opts.Endpoint,
+		"instanceId", opts.InstanceId,
+		"groupId", opts.GroupId,
+	)
+	credentials := opts.GetCredentials()
+
+	producerOpts := []producer.Option{
+		producer.WithNameServer([]string{opts.Endpoint}),
+		producer.WithNamespace(opts.InstanceId),
+		producer.WithInstanceName(opts.InstanceId),
+		producer.WithGroupName(opts.GroupId),
+		producer.WithCredentials(credentials),
+		producer.WithTrace(&primitive.TraceConfig{
+			GroupName:    opts.GroupId,
+			Access:       primitive.Cloud,
+			NamesrvAddrs: []string{opts.Endpoint},
+			Credentials:  credentials,
+		}),
+	}
+
+	tp, err := rocketmq.NewTransactionProducer(listener, producerOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tp.Start(); err != nil {
+		return nil, err
+	}
+
+	if opts.MultipleInstances {
+		rh.mu.Lock()
+		rh.extraTransactionProducers = append(rh.extraTransactionProducers, tp)
+		rh.mu.Unlock()
+		return tp, nil
+	}
+
+	rh.mu.Lock()
+	old := rh.transactionProducer
+	rh.transactionProducer = tp
+	rh.mu.Unlock()
+	if old != nil {
+		if shutdownErr := old.Shutdown(); shutdownErr != nil {
+			opts.logger().Warnw("shutdown-replaced-rocketmq-transaction-producer-failed", "error", shutdownErr)
+		}
+	}
+	return tp, nil
+}
+
+// SendTransactionMessage 发送事务消息，本地事务逻辑由 NewTransactionProducer 传入的 listener 执行。
+// 发送前必须先调用一次 NewTransactionProducer 完成事务生产者的创建。opts.MultipleInstances 为 true 时
+// NewTransactionProducer 创建的生产者不会被本方法使用，本方法始终返回未初始化错误，需直接调用返回的
+// 生产者的 SendMessageInTransaction。
+func (rh *RocketHelper) SendTransactionMessage(ctx context.Context, msg *primitive.Message) (*primitive.TransactionSendResult, error) {
+	rh.mu.Lock()
+	tp := rh.transactionProducer
+	rh.mu.Unlock()
+	if tp == nil {
+		return nil, errors.New("transaction producer not initialized, call NewTransactionProducer first")
+	}
+	logger := rh.opts.logger()
+	logger.Debugw("send-rocketmq-transaction-message", "topic", msg.Topic)
+	res, err := tp.SendMessageInTransaction(ctx, msg)
+	if err != nil {
+		logger.Warnw("send-rocketmq-transaction-message-failed", "error", err)
+		return nil, err
+	}
+	return res, nil
+}